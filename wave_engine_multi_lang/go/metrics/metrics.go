@@ -0,0 +1,48 @@
+// Package metrics provides the Prometheus collectors used to instrument
+// WaveEngine when it is deployed as a long-running, cloud-native service
+// rather than invoked as a one-shot demo.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Set bundles the collectors that track a WaveEngine's runtime behavior:
+// call latency, throughput, concurrency, and the distribution of the wave
+// values it produces.
+type Set struct {
+	ProcessLatency     prometheus.Histogram
+	SymbolsProcessed   prometheus.Counter
+	InFlightGoroutines prometheus.Gauge
+	WaveValue          prometheus.Histogram
+}
+
+// New builds a Set and registers it with reg.
+func New(reg prometheus.Registerer) *Set {
+	s := &Set{
+		ProcessLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:                        "wave_engine_process_seconds",
+			Help:                        "Latency of WaveEngine.Process and GetActivation calls.",
+			NativeHistogramBucketFactor: 1.1,
+		}),
+		SymbolsProcessed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "wave_engine_symbols_processed_total",
+			Help: "Total number of symbols processed across all calls.",
+		}),
+		InFlightGoroutines: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wave_engine_inflight_goroutines",
+			Help: "Number of goroutines currently computing a wave activation.",
+		}),
+		WaveValue: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:                        "wave_engine_wave_value_abs",
+			Help:                        "Distribution of |waveValue| produced per call.",
+			NativeHistogramBucketFactor: 1.1,
+		}),
+	}
+	reg.MustRegister(s.ProcessLatency, s.SymbolsProcessed, s.InFlightGoroutines, s.WaveValue)
+	return s
+}
+
+// List returns the collectors so callers can register the Set with a
+// registry of their own choosing.
+func (s *Set) List() []prometheus.Collector {
+	return []prometheus.Collector{s.ProcessLatency, s.SymbolsProcessed, s.InFlightGoroutines, s.WaveValue}
+}
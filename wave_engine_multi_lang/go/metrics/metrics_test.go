@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestNewRegistersAllCollectors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	s := New(reg)
+
+	s.SymbolsProcessed.Inc()
+	s.ProcessLatency.Observe(0.01)
+	s.WaveValue.Observe(0.5)
+	s.InFlightGoroutines.Inc()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	if got, want := len(families), len(s.List()); got != want {
+		t.Fatalf("expected %d metric families after recording, got %d", want, got)
+	}
+}
+
+func TestListReturnsAllFourCollectors(t *testing.T) {
+	s := New(prometheus.NewRegistry())
+	if got := len(s.List()); got != 4 {
+		t.Fatalf("expected 4 collectors, got %d", got)
+	}
+}
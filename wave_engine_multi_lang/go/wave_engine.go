@@ -7,74 +7,523 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"hash/fnv"
 	"math"
+	"net/http"
 	"sync"
 	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/midatlanticAI/atlandemo/wave_engine_multi_lang/go/metrics"
 )
 
+// Hasher maps a symbol to a 64-bit digest used to derive its wave
+// properties. Implementations must be safe for concurrent use.
+type Hasher interface {
+	Sum64(s string) uint64
+}
+
+// XXHash64 hashes symbols with xxHash, which is faster than FNV but has no
+// cross-language stability guarantee. Prefer it when every engine instance
+// in a process is Go and raw throughput matters more than portability.
+type XXHash64 struct{}
+
+// Sum64 implements Hasher.
+func (XXHash64) Sum64(s string) uint64 {
+	return xxhash.Sum64String(s)
+}
+
+// StableHasher computes FNV-1a over the UTF-8 bytes of a symbol using the
+// standard 64-bit offset basis (0xcbf29ce484222325) and prime
+// (0x100000001b3). Unlike the old fnv32a-based hashCode, this digest is
+// fully specified and produces identical results in any language that
+// implements FNV-1a-64 the same way, so Python, Rust, and Go ports of the
+// wave engine agree bit-for-bit. See testdata/wave_vectors.json for
+// reference vectors.
+type StableHasher struct{}
+
+// Sum64 implements Hasher.
+func (StableHasher) Sum64(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
 // WaveEngine represents the wave-based cognition engine
 type WaveEngine struct {
 	activeWaves map[string]float64
 	mutex       sync.RWMutex
+	hasher      Hasher
+	metrics     *metrics.Set
+	registry    *prometheus.Registry
+	workers     int
+	jobs        chan waveJob
+	cadence     *Cadence
+}
+
+// Cadence broadcasts a single shared clock to every worker in Process, so
+// concurrent symbols sample the same elapsed time instead of drifting apart
+// by however long their own time.Now() call took to get scheduled. A
+// background goroutine ticks every d, publishes the shared time under a
+// lock, and wakes every waiting goroutine with sync.Cond.Broadcast.
+type Cadence struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	t      float64
+	start  time.Time
+	ticker *time.Ticker
+	stop   chan struct{}
+}
+
+func newCadence(d time.Duration) *Cadence {
+	c := &Cadence{
+		start:  time.Now(),
+		ticker: time.NewTicker(d),
+		stop:   make(chan struct{}),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	go c.run()
+	return c
+}
+
+func (c *Cadence) run() {
+	for {
+		select {
+		case now := <-c.ticker.C:
+			c.mu.Lock()
+			c.t = now.Sub(c.start).Seconds()
+			c.mu.Unlock()
+			c.cond.Broadcast()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Wait blocks until the next tick publishes a new shared time, then returns
+// it. Every goroutine that calls Wait between two ticks wakes with the same
+// value, giving them a coherent, phase-locked clock.
+func (c *Cadence) Wait() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := c.t
+	for c.t == last {
+		c.cond.Wait()
+	}
+	return c.t
+}
+
+// Stop tears down the cadence's ticker and background goroutine.
+func (c *Cadence) Stop() {
+	close(c.stop)
+	c.ticker.Stop()
+}
+
+// waveJob is one symbol's work item handed to a pooled worker goroutine.
+// result and sampleTime are shared across every job from the same Process
+// call; each job only ever touches result[index], so no synchronization is
+// needed between workers.
+type waveJob struct {
+	symbol     string
+	index      int
+	result     []float64
+	sampleTime func() float64
+	wg         *sync.WaitGroup
+}
+
+// activationMapPoolCap bounds how large a map ReleaseActivation will return
+// to the pool. Calls processing unusually large symbol sets are allowed to
+// grow a map past this without pinning that memory in the pool forever.
+const activationMapPoolCap = 4096
+
+var activationMapPool = sync.Pool{
+	New: func() any { return make(map[string]float64) },
+}
+
+// ReleaseActivation returns an activation map obtained from Process back to
+// the internal pool so a future call can reuse its backing storage instead
+// of allocating a fresh map. It is optional: callers that don't call it
+// simply let the map be garbage collected as usual.
+func ReleaseActivation(field map[string]float64) {
+	if len(field) > activationMapPoolCap {
+		return
+	}
+	for k := range field {
+		delete(field, k)
+	}
+	activationMapPool.Put(field)
+}
+
+// Option configures a WaveEngine at construction time.
+type Option func(*WaveEngine)
+
+// WithHasher overrides the Hasher used to derive wave properties from
+// symbols. The default is StableHasher, so engines agree with the
+// Python and Rust ports unless this is set.
+func WithHasher(h Hasher) Option {
+	return func(we *WaveEngine) {
+		we.hasher = h
+	}
+}
+
+// WithMetrics instruments the engine with Prometheus collectors registered
+// against reg. reg is a concrete *prometheus.Registry, rather than the
+// narrower Registerer interface, so ServeMetrics can later serve exactly
+// this registry instead of silently falling back to the global default one.
+// Without this option the engine records nothing, so disabled
+// instrumentation costs only a nil check at each recording point.
+func WithMetrics(reg *prometheus.Registry) Option {
+	return func(we *WaveEngine) {
+		we.metrics = metrics.New(reg)
+		we.registry = reg
+	}
+}
+
+// WithWorkers bounds Process to a fixed pool of n goroutines that persist
+// for the life of the engine instead of spawning one goroutine per symbol
+// on every call. Use it under microservice load with large symbol sets,
+// where per-call goroutine churn dominates.
+func WithWorkers(n int) Option {
+	return func(we *WaveEngine) {
+		we.workers = n
+	}
+}
+
+// Collectors returns the engine's Prometheus collectors so callers can
+// register them with a registry of their own, or nil if WithMetrics was
+// never set.
+func (we *WaveEngine) Collectors() []prometheus.Collector {
+	if we.metrics == nil {
+		return nil
+	}
+	return we.metrics.List()
+}
+
+// ServeMetrics is a convenience that serves the Prometheus handler for the
+// registry passed to WithMetrics on addr. It blocks until the server stops
+// or errors, matching http.ListenAndServe. It returns an error immediately
+// if WithMetrics was never set, since there would be nothing to serve.
+func (we *WaveEngine) ServeMetrics(addr string) error {
+	if we.registry == nil {
+		return fmt.Errorf("wave_engine: ServeMetrics requires WithMetrics to be set")
+	}
+	return http.ListenAndServe(addr, promhttp.HandlerFor(we.registry, promhttp.HandlerOpts{}))
+}
+
+// Activation is a single symbol's wave value sampled at a point in time.
+// It is the unit emitted by ProcessStream.
+type Activation struct {
+	Symbol string
+	Time   float64
+	Value  float64
 }
 
 // NewWaveEngine creates a new wave engine instance
-func NewWaveEngine() *WaveEngine {
-	return &WaveEngine{
+func NewWaveEngine(opts ...Option) *WaveEngine {
+	we := &WaveEngine{
 		activeWaves: make(map[string]float64),
+		hasher:      StableHasher{},
+	}
+	for _, opt := range opts {
+		opt(we)
+	}
+	if we.workers > 0 {
+		we.startWorkers(we.workers)
 	}
+	return we
 }
 
-// hashCode generates a hash code for a string (compatible with Python's hash() % operation)
-func (we *WaveEngine) hashCode(s string) uint32 {
-	h := fnv.New32a()
-	h.Write([]byte(s))
-	return h.Sum32()
+// NewWaveEngineWithCadence creates a WaveEngine whose Process calls sample a
+// shared Cadence clock ticking every d, instead of each goroutine calling
+// time.Now() independently. This gives genuine phase-locked sampling across
+// symbols, so their wave values can interfere (sum of coherent sinusoids)
+// instead of being jittered apart by goroutine scheduling. Call Stop when
+// the engine is no longer needed to tear the ticker down.
+func NewWaveEngineWithCadence(d time.Duration) *WaveEngine {
+	we := NewWaveEngine()
+	we.cadence = newCadence(d)
+	return we
+}
+
+// Stop tears down the background goroutines the engine owns: the cadence
+// ticker, if one was configured via NewWaveEngineWithCadence, and the
+// worker pool, if one was configured via WithWorkers. Callers that built an
+// engine with either option and intend to discard it should call Stop, or
+// those goroutines leak for the life of the process. It is safe to call on
+// an engine with neither. Stop must not be called concurrently with
+// Process/ProcessBatch, and must only be called once.
+func (we *WaveEngine) Stop() {
+	if we.cadence != nil {
+		we.cadence.Stop()
+	}
+	if we.jobs != nil {
+		close(we.jobs)
+	}
+}
+
+// startWorkers launches a fixed pool of n goroutines that pull waveJobs off
+// a shared channel for the lifetime of the engine, so Process reuses
+// goroutines across calls instead of spawning one per symbol.
+func (we *WaveEngine) startWorkers(n int) {
+	we.jobs = make(chan waveJob, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			for job := range we.jobs {
+				we.computeInto(job.symbol, job.index, job.result, job.sampleTime())
+				if we.metrics != nil {
+					we.metrics.InFlightGoroutines.Dec()
+				}
+				job.wg.Done()
+			}
+		}()
+	}
+}
+
+// hashCode generates a 64-bit hash code for a string using the engine's
+// configured Hasher (StableHasher by default).
+func (we *WaveEngine) hashCode(s string) uint64 {
+	return we.hasher.Sum64(s)
+}
+
+// waveProperties derives the frequency, amplitude, and phase that shape
+// sym's wave from the engine's configured Hasher. Every code path that
+// computes a wave value (Process, ProcessBatch, ProcessStream,
+// GetActivation) derives its properties here so they can't drift apart.
+func (we *WaveEngine) waveProperties(sym string) (frequency, amplitude, phase float64) {
+	frequency = 1.0 + float64(we.hashCode(sym)%100)/100.0
+	amplitude = 0.5 + float64(len(sym)%10)/20.0
+	phase = float64(we.hashCode(sym)%628)/100.0
+	return frequency, amplitude, phase
 }
 
-// Process processes symbols through wave interference
+// sampleTimeFunc returns the clock a single Process/ProcessBatch call
+// should sample against. When the engine has a Cadence configured, it
+// waits for the next tick exactly once, here, before any symbol is
+// dispatched, and every symbol in the call shares that one value — if each
+// goroutine instead called Cadence.Wait() independently, staggered
+// dispatch (e.g. WithWorkers with more symbols than workers) could land
+// different symbols on different ticks, defeating phase-locked sampling.
+// Without a Cadence, the returned closure reports elapsed time since
+// startTime independently on every call, preserving each goroutine's own
+// timing.
+func (we *WaveEngine) sampleTimeFunc(startTime time.Time) func() float64 {
+	if we.cadence == nil {
+		return func() float64 {
+			return time.Since(startTime).Seconds()
+		}
+	}
+	t := we.cadence.Wait()
+	return func() float64 {
+		return t
+	}
+}
+
+// computeInto derives sym's wave value at time t and writes it to
+// result[index]. It never touches any other index, so concurrent callers
+// writing to disjoint indices of the same result slice need no lock.
+func (we *WaveEngine) computeInto(sym string, index int, result []float64, t float64) {
+	frequency, amplitude, phase := we.waveProperties(sym)
+
+	waveValue := amplitude * math.Sin(2*math.Pi*frequency*t+phase)
+	result[index] = waveValue
+
+	if we.metrics != nil {
+		we.metrics.SymbolsProcessed.Inc()
+		we.metrics.WaveValue.Observe(math.Abs(waveValue))
+	}
+}
+
+// Process processes symbols through wave interference. Results are written
+// to a pre-sized slice indexed by input position rather than guarded by a
+// per-symbol mutex, then assembled into a pooled map once every symbol has
+// been computed. With WithWorkers set, symbols are handed to a reused
+// goroutine pool instead of spawning one goroutine per symbol.
 func (we *WaveEngine) Process(symbols []string) map[string]float64 {
 	startTime := time.Now()
-	activationField := make(map[string]float64)
-	
-	// Use goroutines for concurrent processing
+	results := make([]float64, len(symbols))
+	sampleTime := we.sampleTimeFunc(startTime)
+
 	var wg sync.WaitGroup
-	var mu sync.Mutex
-	
+	wg.Add(len(symbols))
+
+	for i, symbol := range symbols {
+		if we.metrics != nil {
+			we.metrics.InFlightGoroutines.Inc()
+		}
+		if we.workers > 0 {
+			we.jobs <- waveJob{symbol: symbol, index: i, result: results, sampleTime: sampleTime, wg: &wg}
+			continue
+		}
+		go func(i int, sym string) {
+			defer wg.Done()
+			defer func() {
+				if we.metrics != nil {
+					we.metrics.InFlightGoroutines.Dec()
+				}
+			}()
+			we.computeInto(sym, i, results, sampleTime())
+		}(i, symbol)
+	}
+
+	wg.Wait()
+
+	activationField := activationMapPool.Get().(map[string]float64)
+	for i, symbol := range symbols {
+		activationField[symbol] = results[i]
+	}
+
+	if we.metrics != nil {
+		we.metrics.ProcessLatency.Observe(time.Since(startTime).Seconds())
+	}
+	return activationField
+}
+
+// ProcessBatch is the context-aware counterpart to Process. It aborts early
+// with ctx.Err() if ctx is cancelled (deadline exceeded, client disconnect,
+// shutdown signal) before every symbol has finished, letting Process keep
+// its original signature. Like Process, it writes into a pre-sized slice
+// via computeInto, assembles a pooled map only once every symbol is done,
+// samples a configured Cadence once for the whole call, and hands symbols
+// to the worker pool when WithWorkers is set.
+func (we *WaveEngine) ProcessBatch(ctx context.Context, symbols []string) (map[string]float64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	startTime := time.Now()
+	results := make([]float64, len(symbols))
+	sampleTime := we.sampleTimeFunc(startTime)
+
+	var wg sync.WaitGroup
+	wg.Add(len(symbols))
+
+	for i, symbol := range symbols {
+		if we.metrics != nil {
+			we.metrics.InFlightGoroutines.Inc()
+		}
+		if we.workers > 0 {
+			we.jobs <- waveJob{symbol: symbol, index: i, result: results, sampleTime: sampleTime, wg: &wg}
+			continue
+		}
+		go func(i int, sym string) {
+			defer wg.Done()
+			defer func() {
+				if we.metrics != nil {
+					we.metrics.InFlightGoroutines.Dec()
+				}
+			}()
+			we.computeInto(sym, i, results, sampleTime())
+		}(i, symbol)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-done:
+	}
+
+	activationField := activationMapPool.Get().(map[string]float64)
+	for i, symbol := range symbols {
+		activationField[symbol] = results[i]
+	}
+
+	if we.metrics != nil {
+		we.metrics.ProcessLatency.Observe(time.Since(startTime).Seconds())
+	}
+	return activationField, nil
+}
+
+// ProcessCtx is an alias for ProcessBatch, kept so callers pairing a one-shot
+// call with ProcessStream can use matching *Ctx/*Stream naming.
+func (we *WaveEngine) ProcessCtx(ctx context.Context, symbols []string) (map[string]float64, error) {
+	return we.ProcessBatch(ctx, symbols)
+}
+
+// ProcessStream processes symbols continuously, emitting one Activation per
+// symbol per tick until ctx is cancelled. Each symbol runs on its own
+// goroutine and checks a shared stop channel between ticks (the same
+// stopChan pattern used by long-running VDF-style compute) so that workers
+// drain cleanly instead of leaking when a caller's deadline or shutdown
+// signal fires.
+func (we *WaveEngine) ProcessStream(ctx context.Context, symbols []string, tick time.Duration) (<-chan Activation, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("wave_engine: ProcessStream requires at least one symbol")
+	}
+	if tick <= 0 {
+		return nil, fmt.Errorf("wave_engine: tick must be positive, got %s", tick)
+	}
+
+	out := make(chan Activation)
+	stopChan := make(chan struct{})
+
+	go func() {
+		<-ctx.Done()
+		close(stopChan)
+	}()
+
+	var wg sync.WaitGroup
+	startTime := time.Now()
+
 	for _, symbol := range symbols {
 		wg.Add(1)
 		go func(sym string) {
 			defer wg.Done()
-			
-			// Create wave with symbol-based properties (exact same algorithm as Python)
-			frequency := 1.0 + float64(we.hashCode(sym)%100)/100.0
-			amplitude := 0.5 + float64(len(sym)%10)/20.0
-			phase := float64(we.hashCode(sym)%628)/100.0
-			
-			// Calculate activation
-			currentTime := time.Now()
-			timeDiff := currentTime.Sub(startTime).Seconds()
-			waveValue := amplitude * math.Sin(2*math.Pi*frequency*timeDiff+phase)
-			
-			mu.Lock()
-			activationField[sym] = waveValue
-			mu.Unlock()
+
+			ticker := time.NewTicker(tick)
+			defer ticker.Stop()
+
+			frequency, amplitude, phase := we.waveProperties(sym)
+
+			for {
+				select {
+				case <-stopChan:
+					return
+				case now := <-ticker.C:
+					timeDiff := now.Sub(startTime).Seconds()
+					waveValue := amplitude * math.Sin(2*math.Pi*frequency*timeDiff+phase)
+
+					select {
+					case out <- Activation{Symbol: sym, Time: timeDiff, Value: waveValue}:
+					case <-stopChan:
+						return
+					}
+				}
+			}
 		}(symbol)
 	}
-	
-	wg.Wait()
-	return activationField
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
 }
 
 // GetActivation gets current activation for a symbol
-func (we *WaveEngine) GetActivation(symbol string, time float64) float64 {
-	frequency := 1.0 + float64(we.hashCode(symbol)%100)/100.0
-	amplitude := 0.5 + float64(len(symbol)%10)/20.0
-	phase := float64(we.hashCode(symbol)%628)/100.0
-	
-	return amplitude * math.Sin(2*math.Pi*frequency*time+phase)
+func (we *WaveEngine) GetActivation(symbol string, t float64) float64 {
+	start := time.Now()
+
+	frequency, amplitude, phase := we.waveProperties(symbol)
+	waveValue := amplitude * math.Sin(2*math.Pi*frequency*t+phase)
+
+	if we.metrics != nil {
+		we.metrics.SymbolsProcessed.Inc()
+		we.metrics.WaveValue.Observe(math.Abs(waveValue))
+		we.metrics.ProcessLatency.Observe(time.Since(start).Seconds())
+	}
+
+	return waveValue
 }
 
 // ReplicationTest runs the replication test
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func benchSymbols(n int) []string {
+	symbols := make([]string, n)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("symbol-%d", i)
+	}
+	return symbols
+}
+
+func BenchmarkProcess_10Symbols(b *testing.B) {
+	benchmarkProcess(b, 10)
+}
+
+func BenchmarkProcess_1000Symbols(b *testing.B) {
+	benchmarkProcess(b, 1000)
+}
+
+func BenchmarkProcess_100000Symbols(b *testing.B) {
+	benchmarkProcess(b, 100000)
+}
+
+func benchmarkProcess(b *testing.B, n int) {
+	symbols := benchSymbols(n)
+	engine := NewWaveEngine()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result := engine.Process(symbols)
+		ReleaseActivation(result)
+	}
+}
+
+func BenchmarkProcessLegacy_10Symbols(b *testing.B) {
+	benchmarkProcessLegacy(b, 10)
+}
+
+func BenchmarkProcessLegacy_1000Symbols(b *testing.B) {
+	benchmarkProcessLegacy(b, 1000)
+}
+
+func BenchmarkProcessLegacy_100000Symbols(b *testing.B) {
+	benchmarkProcessLegacy(b, 100000)
+}
+
+func benchmarkProcessLegacy(b *testing.B, n int) {
+	symbols := benchSymbols(n)
+	engine := NewWaveEngine()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		engine.processLegacy(symbols)
+	}
+}
+
+// processLegacy reproduces Process as it existed before sync.Pool and
+// WithWorkers were introduced: a fresh map per call, one goroutine per
+// symbol, and a mutex guarding every write. It exists only so
+// BenchmarkProcessLegacy_* has a real baseline to compare BenchmarkProcess_*
+// against, since the rewrite replaced that code in place.
+func (we *WaveEngine) processLegacy(symbols []string) map[string]float64 {
+	activationField := make(map[string]float64)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(len(symbols))
+
+	startTime := time.Now()
+	for _, symbol := range symbols {
+		go func(sym string) {
+			defer wg.Done()
+			frequency, amplitude, phase := we.waveProperties(sym)
+			t := time.Since(startTime).Seconds()
+			waveValue := amplitude * math.Sin(2*math.Pi*frequency*t+phase)
+
+			mutex.Lock()
+			activationField[sym] = waveValue
+			mutex.Unlock()
+		}(symbol)
+	}
+
+	wg.Wait()
+	return activationField
+}
+
+// TestProcessSliceAggregation exercises Process with a bounded worker pool
+// under -race to confirm the slice-indexed aggregation introduced for
+// worker reuse assembles the result map correctly with no data races.
+func TestProcessSliceAggregation(t *testing.T) {
+	symbols := benchSymbols(256)
+	engine := NewWaveEngine(WithWorkers(4))
+
+	result := engine.Process(symbols)
+	if len(result) != len(symbols) {
+		t.Fatalf("expected %d activations, got %d", len(symbols), len(result))
+	}
+	for _, sym := range symbols {
+		if _, ok := result[sym]; !ok {
+			t.Fatalf("missing activation for %q", sym)
+		}
+	}
+}
+
+// TestProcessBatchSliceAggregation exercises ProcessBatch with a bounded
+// worker pool under -race to confirm it shares Process's slice-indexed
+// aggregation correctly rather than its own now-removed mutex-guarded map.
+func TestProcessBatchSliceAggregation(t *testing.T) {
+	symbols := benchSymbols(256)
+	engine := NewWaveEngine(WithWorkers(4))
+
+	result, err := engine.ProcessBatch(context.Background(), symbols)
+	if err != nil {
+		t.Fatalf("ProcessBatch: %v", err)
+	}
+	if len(result) != len(symbols) {
+		t.Fatalf("expected %d activations, got %d", len(symbols), len(result))
+	}
+}
+
+func TestProcessBatchReturnsCtxErrOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	engine := NewWaveEngine()
+	if _, err := engine.ProcessBatch(ctx, benchSymbols(8)); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+// TestStopTerminatesWorkerPool guards against the WithWorkers goroutine
+// leak: without Stop closing we.jobs, the pool's goroutines run for the
+// life of the process even after the engine itself is discarded.
+func TestStopTerminatesWorkerPool(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	engine := NewWaveEngine(WithWorkers(8))
+	ReleaseActivation(engine.Process(benchSymbols(32)))
+	engine.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := runtime.NumGoroutine(); got > before {
+		t.Fatalf("worker goroutines still running after Stop: NumGoroutine()=%d, baseline=%d", got, before)
+	}
+}
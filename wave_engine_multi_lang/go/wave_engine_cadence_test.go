@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSampleTimeFuncIsStableWithinACall guards against regressing to a
+// per-goroutine Cadence.Wait(): sampleTimeFunc must be called once per
+// Process call and its closure must keep returning that same value no
+// matter how many symbols consult it, even if dispatch to those symbols is
+// staggered across ticks.
+func TestSampleTimeFuncIsStableWithinACall(t *testing.T) {
+	engine := NewWaveEngineWithCadence(5 * time.Millisecond)
+	defer engine.Stop()
+
+	time.Sleep(20 * time.Millisecond) // let the cadence tick at least once
+
+	sample := engine.sampleTimeFunc(time.Now())
+	first := sample()
+	for i := 0; i < 100; i++ {
+		time.Sleep(time.Millisecond) // cross several ticks while still inside "one call"
+		if got := sample(); got != first {
+			t.Fatalf("sampleTime drifted within a single call: got %v, want %v", got, first)
+		}
+	}
+}
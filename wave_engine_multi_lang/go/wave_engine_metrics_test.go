@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWithMetricsRecordsAgainstTheGivenRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	engine := NewWaveEngine(WithMetrics(reg))
+
+	result := engine.Process([]string{"thinking", "mind"})
+	ReleaseActivation(result)
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	if got, want := len(families), len(engine.Collectors()); got != want {
+		t.Fatalf("expected %d metric families registered against reg, got %d", want, got)
+	}
+}
+
+func TestServeMetricsErrorsWithoutWithMetrics(t *testing.T) {
+	engine := NewWaveEngine()
+	if err := engine.ServeMetrics(":0"); err == nil {
+		t.Fatal("expected an error when WithMetrics was never set, got nil")
+	}
+}
+
+func TestCollectorsIsNilWithoutWithMetrics(t *testing.T) {
+	engine := NewWaveEngine()
+	if got := engine.Collectors(); got != nil {
+		t.Fatalf("expected nil Collectors without WithMetrics, got %v", got)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// waitForGoroutineBaseline polls runtime.NumGoroutine() until it returns to
+// at most before, or the deadline elapses, mirroring the drain-detection
+// loop TestStopTerminatesWorkerPool uses for the worker pool.
+func waitForGoroutineBaseline(t *testing.T, before int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := runtime.NumGoroutine(); got > before {
+		t.Fatalf("goroutines still running after cancellation: NumGoroutine()=%d, baseline=%d", got, before)
+	}
+}
+
+// TestProcessStreamDrainsOnCancelWhileConsuming cancels a ProcessStream
+// context while its consumer keeps reading out, and asserts every per-symbol
+// ticker goroutine and the ctx.Done-to-stopChan translator goroutine exit
+// instead of leaking.
+func TestProcessStreamDrainsOnCancelWhileConsuming(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	engine := NewWaveEngine()
+	out, err := engine.ProcessStream(ctx, benchSymbols(8), time.Millisecond)
+	if err != nil {
+		t.Fatalf("ProcessStream: %v", err)
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		for range out {
+		}
+		close(drained)
+	}()
+
+	time.Sleep(10 * time.Millisecond) // let a few ticks flow first
+	cancel()
+
+	select {
+	case <-drained:
+	case <-time.After(time.Second):
+		t.Fatal("consumer never observed out close after cancellation")
+	}
+
+	waitForGoroutineBaseline(t, before)
+}
+
+// TestProcessStreamDrainsOnCancelWithoutConsuming cancels a ProcessStream
+// context while its consumer has stopped reading out entirely (so per-symbol
+// goroutines are blocked on out<-Activation), and asserts they still drain
+// via the stopChan case in that send's select rather than leaking blocked
+// forever on a send nobody will receive.
+func TestProcessStreamDrainsOnCancelWithoutConsuming(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	engine := NewWaveEngine()
+	if _, err := engine.ProcessStream(ctx, benchSymbols(8), time.Millisecond); err != nil {
+		t.Fatalf("ProcessStream: %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond) // let the goroutines block on a send
+	cancel()
+
+	waitForGoroutineBaseline(t, before)
+}
+
+func TestProcessStreamRequiresSymbols(t *testing.T) {
+	engine := NewWaveEngine()
+	if _, err := engine.ProcessStream(context.Background(), nil, time.Millisecond); err == nil {
+		t.Fatal("expected an error for empty symbols, got nil")
+	}
+}
+
+func TestProcessStreamRequiresPositiveTick(t *testing.T) {
+	engine := NewWaveEngine()
+	if _, err := engine.ProcessStream(context.Background(), []string{"thinking"}, 0); err == nil {
+		t.Fatal("expected an error for a non-positive tick, got nil")
+	}
+}
+
+// TestProcessCtxReturnsActivations is a basic happy-path check for the
+// ProcessCtx alias, since it otherwise only inherits ProcessBatch's coverage
+// indirectly.
+func TestProcessCtxReturnsActivations(t *testing.T) {
+	engine := NewWaveEngine()
+	result, err := engine.ProcessCtx(context.Background(), []string{"thinking", "mind"})
+	if err != nil {
+		t.Fatalf("ProcessCtx: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 activations, got %d", len(result))
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// vectorEpsilon tolerates the float64 rounding introduced by round-tripping
+// reference values through JSON and through independent arithmetic
+// (1.0 + 84.0/100.0 isn't bit-identical to the nearest double to "1.84"),
+// while still catching a real formula or hashing regression.
+const vectorEpsilon = 1e-9
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < vectorEpsilon
+}
+
+type waveVectorSample struct {
+	Time  float64 `json:"time"`
+	Value float64 `json:"value"`
+}
+
+type waveVector struct {
+	Symbol    string             `json:"symbol"`
+	FNV1a64   string             `json:"fnv1a64"`
+	Frequency float64            `json:"frequency"`
+	Amplitude float64            `json:"amplitude"`
+	Phase     float64            `json:"phase"`
+	Samples   []waveVectorSample `json:"samples"`
+}
+
+type waveVectorFile struct {
+	Vectors []waveVector `json:"vectors"`
+}
+
+// TestStableHasherMatchesReferenceVectors unmarshals testdata/wave_vectors.json
+// and asserts that StableHasher still reproduces each hash exactly, and that
+// the derived wave properties and GetActivation reproduce the recorded values
+// to within vectorEpsilon. The vectors exist so other
+// language ports can verify they agree with Go; this test is what keeps Go
+// itself from silently drifting away from its own fixture.
+func TestStableHasherMatchesReferenceVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/wave_vectors.json")
+	if err != nil {
+		t.Fatalf("reading testdata/wave_vectors.json: %v", err)
+	}
+
+	var file waveVectorFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("unmarshalling testdata/wave_vectors.json: %v", err)
+	}
+	if len(file.Vectors) == 0 {
+		t.Fatal("expected at least one vector in testdata/wave_vectors.json")
+	}
+
+	engine := NewWaveEngine()
+	for _, vec := range file.Vectors {
+		vec := vec
+		t.Run(vec.Symbol, func(t *testing.T) {
+			wantHash, err := strconv.ParseUint(vec.FNV1a64, 10, 64)
+			if err != nil {
+				t.Fatalf("parsing fnv1a64 %q: %v", vec.FNV1a64, err)
+			}
+			if got := (StableHasher{}).Sum64(vec.Symbol); got != wantHash {
+				t.Errorf("StableHasher{}.Sum64(%q) = %d, want %d", vec.Symbol, got, wantHash)
+			}
+
+			frequency, amplitude, phase := engine.waveProperties(vec.Symbol)
+			if !approxEqual(frequency, vec.Frequency) {
+				t.Errorf("frequency = %v, want %v", frequency, vec.Frequency)
+			}
+			if !approxEqual(amplitude, vec.Amplitude) {
+				t.Errorf("amplitude = %v, want %v", amplitude, vec.Amplitude)
+			}
+			if !approxEqual(phase, vec.Phase) {
+				t.Errorf("phase = %v, want %v", phase, vec.Phase)
+			}
+
+			for _, sample := range vec.Samples {
+				if got := engine.GetActivation(vec.Symbol, sample.Time); !approxEqual(got, sample.Value) {
+					t.Errorf("GetActivation(%q, %v) = %v, want %v", vec.Symbol, sample.Time, got, sample.Value)
+				}
+			}
+		})
+	}
+}
+
+// TestWithHasherOverridesStableHasher confirms WithHasher actually swaps the
+// digest waveProperties derives from, rather than StableHasher silently
+// winning regardless of what's passed in.
+func TestWithHasherOverridesStableHasher(t *testing.T) {
+	const symbol = "thinking"
+
+	stable := NewWaveEngine()
+	xx := NewWaveEngine(WithHasher(XXHash64{}))
+
+	sFreq, sAmp, sPhase := stable.waveProperties(symbol)
+	xFreq, xAmp, xPhase := xx.waveProperties(symbol)
+
+	if sFreq == xFreq && sAmp == xAmp && sPhase == xPhase {
+		t.Fatalf("waveProperties(%q) identical under StableHasher and XXHash64; WithHasher had no effect", symbol)
+	}
+	if got := (XXHash64{}).Sum64(symbol); got == (StableHasher{}).Sum64(symbol) {
+		t.Fatalf("XXHash64.Sum64(%q) collided with StableHasher.Sum64(%q); test fixture can't distinguish them", symbol, symbol)
+	}
+}